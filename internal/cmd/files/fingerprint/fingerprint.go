@@ -3,22 +3,40 @@ package fingerprint
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/file/debrickedignore"
+	"github.com/debricked/cli/internal/fingerprint"
+	"github.com/debricked/cli/internal/sign"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-var exclusions = file.DefaultExclusionsFingerprint()
+var exclusions = fingerprint.DefaultExclusionsFingerprint()
+var hashAlgorithms = []string{fingerprint.DefaultHashAlgorithm}
+var signOutput bool
+var signKeyPath string
+var signMode = string(sign.ModeGPG)
+var respectGitignore = file.DefaultRespectGitignore(".")
+var attributeExclusions = file.DefaultAttributeExclusions()
+var printEffectiveExclusions bool
 
 const (
-	ExclusionFlag = "exclusion-fingerprint"
+	ExclusionFlag                = "exclusion-fingerprint"
+	HashAlgorithmFlag            = "hash-algorithm"
+	SignOutputFlag               = "sign-output"
+	SignKeyPathFlag              = "sign-key-path"
+	SignModeFlag                 = "sign-mode"
+	RespectGitignoreFlag         = "respect-gitignore"
+	AttributeExclusionFlag       = "attribute-exclusion"
+	PrintEffectiveExclusionsFlag = "print-effective-exclusions"
 )
 
-func NewFingerprintCmd(fingerprinter file.IFingerprint) *cobra.Command {
+func NewFingerprintCmd(fingerprinter fingerprint.IFingerprint) *cobra.Command {
 
-	short := fmt.Sprintf("Fingerprint files for identification in a given path and writes it to %s. [beta feature]", file.OutputFileNameFingerprints)
-	long := fmt.Sprintf("Fingerprint files for identification in a given path and writes it to %s. [beta feature]\nThis hashes all files and matches them against the Debricked knowledge base.", file.OutputFileNameFingerprints)
+	short := fmt.Sprintf("Fingerprint files for identification in a given path and writes it to %s. [beta feature]", fingerprint.OutputFileNameFingerprints)
+	long := fmt.Sprintf("Fingerprint files for identification in a given path and writes it to %s. [beta feature]\nThis hashes all files and matches them against the Debricked knowledge base.", fingerprint.OutputFileNameFingerprints)
 	cmd := &cobra.Command{
 		Use:   "fingerprint [path]",
 		Short: short,
@@ -34,38 +52,131 @@ func NewFingerprintCmd(fingerprinter file.IFingerprint) *cobra.Command {
 	cmd.Flags().StringArrayVarP(&exclusions, ExclusionFlag, "", exclusions, `The following terms are supported to exclude paths:
 Special Terms | Meaning
 ------------- | -------
-"*"           | matches any sequence of non-Separator characters 
+"*"           | matches any sequence of non-Separator characters
 "/**/"        | matches zero or multiple directories
 "?"           | matches any single non-Separator character
 "[class]"     | matches any single non-Separator character against a class of characters ([see "character classes"])
 "{alt1,...}"  | matches a sequence of characters if one of the comma-separated alternatives matches
 
-Example: 
+Example:
 $ debricked files fingerprint . `+exampleFlags)
 
+	cmd.Flags().StringArrayVarP(
+		&hashAlgorithms,
+		HashAlgorithmFlag,
+		"",
+		hashAlgorithms,
+		fmt.Sprintf(
+			"Hash algorithm(s) to compute per file. Supported: %s. Repeat the flag to compute several at once, e.g. --%s sha256 --%s md5",
+			strings.Join(fingerprint.SupportedHashAlgorithms, ", "),
+			HashAlgorithmFlag,
+			HashAlgorithmFlag,
+		),
+	)
+
+	cmd.Flags().BoolVarP(&signOutput, SignOutputFlag, "", signOutput, fmt.Sprintf("Write a detached signature to %s alongside the fingerprint output", fingerprint.OutputFileNameFingerprints+sign.SigExtension))
+	cmd.Flags().StringVarP(&signKeyPath, SignKeyPathFlag, "", signKeyPath, "Private key path used to sign the fingerprint output")
+	cmd.Flags().StringVarP(&signMode, SignModeFlag, "", signMode, fmt.Sprintf("Signing mode: %s, %s, or %s", sign.ModeGPG, sign.ModeEd25519, sign.ModeCosign))
+	cmd.Flags().BoolVarP(&respectGitignore, RespectGitignoreFlag, "", respectGitignore, "Skip files matched by .gitignore, .git/info/exclude, and the global excludesfile")
+	cmd.Flags().StringArrayVarP(
+		&attributeExclusions,
+		AttributeExclusionFlag,
+		"",
+		attributeExclusions,
+		`Skip files tagged with one of these .gitattributes keys set to "true" (e.g. linguist-vendored). Repeat the flag to add further keys, such as export-ignore.`,
+	)
+	cmd.Flags().BoolVarP(
+		&printEffectiveExclusions,
+		PrintEffectiveExclusionsFlag,
+		"",
+		printEffectiveExclusions,
+		fmt.Sprintf("Print the effective %s exclusions/inclusions (flags, defaults, and .debrickedignore's [%s] section) and exit", ExclusionFlag, debrickedignore.SectionFingerprint),
+	)
+
 	viper.MustBindEnv(ExclusionFlag)
+	viper.MustBindEnv(HashAlgorithmFlag)
+	viper.MustBindEnv(SignOutputFlag)
+	viper.MustBindEnv(SignKeyPathFlag)
+	viper.MustBindEnv(SignModeFlag)
+	viper.MustBindEnv(RespectGitignoreFlag)
+	viper.MustBindEnv(AttributeExclusionFlag)
+	viper.MustBindEnv(PrintEffectiveExclusionsFlag)
 
 	return cmd
 }
 
-func RunE(f file.IFingerprint) func(_ *cobra.Command, args []string) error {
+func RunE(f fingerprint.IFingerprint) func(_ *cobra.Command, args []string) error {
 	return func(_ *cobra.Command, args []string) error {
 		path := ""
 		if len(args) > 0 {
 			path = args[0]
 		}
 
-		output, err := f.FingerprintFiles(path, exclusions)
+		if printEffectiveExclusions {
+			return printEffectiveExclusionsFor(path)
+		}
+
+		fingerprintExclusions := append([]string{}, exclusions...)
+		var fingerprintInclusions []string
+		sources, err := debrickedignore.Load(path, debrickedignore.SectionFingerprint)
+		if err != nil {
+			return err
+		}
+		for _, source := range sources {
+			fingerprintExclusions = append(fingerprintExclusions, source.Exclusions...)
+			fingerprintInclusions = append(fingerprintInclusions, source.Inclusions...)
+		}
+
+		output, err := f.FingerprintFiles(fingerprint.DebrickedOptions{
+			Path:                path,
+			Exclusions:          fingerprintExclusions,
+			Inclusions:          fingerprintInclusions,
+			HashAlgorithms:      hashAlgorithms,
+			RespectGitignore:    respectGitignore,
+			AttributeExclusions: attributeExclusions,
+		})
 
 		if err != nil {
 			return err
 		}
 
-		err = output.ToFile(file.OutputFileNameFingerprints)
+		err = output.ToFile(fingerprint.OutputFileNameFingerprints)
 		if err != nil {
 			return err
 		}
 
+		if signOutput {
+			return sign.SignFile(sign.Mode(signMode), sign.KeyConfig{KeyPath: signKeyPath}, fingerprint.OutputFileNameFingerprints)
+		}
+
 		return nil
 	}
 }
+
+// printEffectiveExclusionsFor prints the fully merged exclusion/inclusion
+// list `debricked files fingerprint` ends up using for path: the
+// --exclusion-fingerprint flags/DEBRICKED_EXCLUSIONS, followed by the
+// nearest .debrickedignore's [fingerprint] section rules, annotated with
+// where each layer came from.
+func printEffectiveExclusionsFor(path string) error {
+	fmt.Println("Effective exclusions (CLI flags / DEBRICKED_EXCLUSIONS / defaults):")
+	for _, exclusion := range exclusions {
+		fmt.Printf("  %s\n", exclusion)
+	}
+
+	sources, err := debrickedignore.Load(path, debrickedignore.SectionFingerprint)
+	if err != nil {
+		return err
+	}
+	for _, source := range sources {
+		fmt.Printf("Effective [fingerprint] exclusions from %s:\n", source.Origin)
+		for _, exclusion := range source.Exclusions {
+			fmt.Printf("  %s\n", exclusion)
+		}
+		for _, inclusion := range source.Inclusions {
+			fmt.Printf("  !%s\n", inclusion)
+		}
+	}
+
+	return nil
+}
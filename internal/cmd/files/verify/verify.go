@@ -0,0 +1,71 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/debricked/cli/internal/sign"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	keyringPath  string
+	certIdentity string
+	oidcIssuer   string
+	mode         = string(sign.ModeGPG)
+)
+
+const (
+	KeyringPathFlag  = "keyring-path"
+	OIDCIssuerFlag   = "oidc-issuer"
+	CertIdentityFlag = "cert-identity"
+	ModeFlag         = "mode"
+)
+
+// NewVerifyCmd creates the `debricked files verify <file>` command, which
+// checks a file written by `debricked files fingerprint` or `debricked
+// scan` against the detached signature written alongside it.
+func NewVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify <file>",
+		Short: "Verify a file's detached signature",
+		Long: fmt.Sprintf(
+			"Verify a file's detached signature, written next to it (\"<file>%s\") by a prior scan or fingerprint run with signing enabled.",
+			sign.SigExtension,
+		),
+		Args: cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE,
+	}
+
+	cmd.Flags().StringVarP(&keyringPath, KeyringPathFlag, "", keyringPath, "Public keyring/key path to verify against (gpg, ed25519)")
+	cmd.Flags().StringVarP(&oidcIssuer, OIDCIssuerFlag, "", oidcIssuer, "Expected OIDC issuer identity for a cosign keyless signature")
+	cmd.Flags().StringVarP(&certIdentity, CertIdentityFlag, "", certIdentity, "Expected signing certificate identity for a cosign keyless signature")
+	cmd.Flags().StringVarP(&mode, ModeFlag, "", mode, fmt.Sprintf("Signing mode: %s, %s, or %s", sign.ModeGPG, sign.ModeEd25519, sign.ModeCosign))
+
+	viper.MustBindEnv(KeyringPathFlag)
+	viper.MustBindEnv(OIDCIssuerFlag)
+	viper.MustBindEnv(CertIdentityFlag)
+	viper.MustBindEnv(ModeFlag)
+
+	return cmd
+}
+
+func RunE(_ *cobra.Command, args []string) error {
+	path := args[0]
+
+	err := sign.VerifyFile(sign.Mode(mode), sign.KeyConfig{
+		KeyringPath:  keyringPath,
+		OIDCIssuer:   oidcIssuer,
+		CertIdentity: certIdentity,
+	}, path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: signature OK\n", path)
+
+	return nil
+}
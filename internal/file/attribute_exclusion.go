@@ -0,0 +1,31 @@
+package file
+
+// DefaultAttributeExclusions are the .gitattributes keys that cause a path
+// to be skipped out of the box: vendored and generated code, plus debricked's
+// own opt-out marker. DebrickedOptions.AttributeExclusions lets users add
+// further keys (e.g. "export-ignore") on top of this list.
+func DefaultAttributeExclusions() []string {
+	return []string{
+		"linguist-vendored",
+		"linguist-generated",
+		"debricked-ignore",
+	}
+}
+
+// ExcludedByAttributes reports whether path should be skipped given its
+// resolved .gitattributes attribute set. A path tagged "debricked-include"
+// is always kept, mirroring the precedence Inclusions already has over
+// Exclusions.
+func ExcludedByAttributes(attributes map[string]string, filterKeys []string) bool {
+	if attributes["debricked-include"] == "true" {
+		return false
+	}
+
+	for _, key := range filterKeys {
+		if attributes[key] == "true" {
+			return true
+		}
+	}
+
+	return false
+}
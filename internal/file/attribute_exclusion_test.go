@@ -0,0 +1,47 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcludedByAttributes(t *testing.T) {
+	cases := []struct {
+		name       string
+		attributes map[string]string
+		filterKeys []string
+		expected   bool
+	}{
+		{
+			name:       "Vendored",
+			attributes: map[string]string{"linguist-vendored": "true"},
+			filterKeys: DefaultAttributeExclusions(),
+			expected:   true,
+		},
+		{
+			name:       "NotTagged",
+			attributes: map[string]string{},
+			filterKeys: DefaultAttributeExclusions(),
+			expected:   false,
+		},
+		{
+			name:       "DebrickedIncludeOverridesVendored",
+			attributes: map[string]string{"linguist-vendored": "true", "debricked-include": "true"},
+			filterKeys: DefaultAttributeExclusions(),
+			expected:   false,
+		},
+		{
+			name:       "OptInKey",
+			attributes: map[string]string{"export-ignore": "true"},
+			filterKeys: append(DefaultAttributeExclusions(), "export-ignore"),
+			expected:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, ExcludedByAttributes(c.attributes, c.filterKeys))
+		})
+	}
+}
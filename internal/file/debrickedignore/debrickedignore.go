@@ -0,0 +1,90 @@
+// Package debrickedignore parses .debrickedignore files: a reviewable,
+// checked-in alternative to repeating --exclusion flags or setting
+// DEBRICKED_EXCLUSIONS for large repos.
+package debrickedignore
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Section names match the subsystems a single .debrickedignore file can
+// scope rules to via a "[section]" header. Rules declared before any
+// header (the global section) apply to every subsystem.
+const (
+	SectionFingerprint = "fingerprint"
+	SectionResolve     = "resolve"
+	SectionScan        = "scan"
+
+	globalSection = ""
+)
+
+// Rules is a set of doublestar exclusion patterns and their "!"-prefixed
+// inclusion overrides.
+type Rules struct {
+	Exclusions []string
+	Inclusions []string
+}
+
+// Config is a fully parsed .debrickedignore file.
+type Config struct {
+	sections map[string]Rules
+}
+
+// Parse reads a .debrickedignore file body. Blank lines and lines starting
+// with "#" are ignored; "[section]" lines switch the active section for
+// the lines that follow; everything else is a doublestar pattern, "!"
+// prefixed to mark it as an inclusion override.
+func Parse(r io.Reader) (*Config, error) {
+	cfg := &Config{sections: map[string]Rules{}}
+	section := globalSection
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimRight(scanner.Text(), "\r"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+
+			continue
+		}
+
+		rules := cfg.sections[section]
+		if strings.HasPrefix(line, "!") {
+			rules.Inclusions = append(rules.Inclusions, strings.TrimPrefix(line, "!"))
+		} else {
+			rules.Exclusions = append(rules.Exclusions, line)
+		}
+		cfg.sections[section] = rules
+	}
+
+	return cfg, scanner.Err()
+}
+
+// For returns the effective Rules for section: the global rules (declared
+// before any "[section]" header) plus any rules declared under that
+// section's own header.
+func (c *Config) For(section string) Rules {
+	global := c.sections[globalSection]
+	scoped := c.sections[section]
+
+	return Rules{
+		Exclusions: concat(global.Exclusions, scoped.Exclusions),
+		Inclusions: concat(global.Inclusions, scoped.Inclusions),
+	}
+}
+
+// concat joins a and b without allocating when both are empty, so callers
+// comparing against a nil-Inclusions Rules literal don't see a spurious
+// empty-slice mismatch.
+func concat(a []string, b []string) []string {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+
+	return append(append([]string{}, a...), b...)
+}
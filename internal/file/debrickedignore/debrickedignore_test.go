@@ -0,0 +1,66 @@
+package debrickedignore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSections(t *testing.T) {
+	body := strings.NewReader(`
+# comment
+**/*.snap
+
+[fingerprint]
+**/testdata/**
+!**/testdata/keep.json
+
+[resolve]
+**/*.lock
+`)
+
+	cfg, err := Parse(body)
+	assert.NoError(t, err)
+
+	assert.Equal(t, Rules{Exclusions: []string{"**/*.snap", "**/testdata/**"}, Inclusions: []string{"**/testdata/keep.json"}}, cfg.For(SectionFingerprint))
+	assert.Equal(t, Rules{Exclusions: []string{"**/*.snap", "**/*.lock"}}, cfg.For(SectionResolve))
+	assert.Equal(t, Rules{Exclusions: []string{"**/*.snap"}}, cfg.For(SectionScan))
+}
+
+func TestFindNearestStopsAtRepoRoot(t *testing.T) {
+	root := t.TempDir()
+	mustMkdirAll(t, filepath.Join(root, ".git"))
+	mustMkdirAll(t, filepath.Join(root, "sub", "deeper"))
+	mustWriteFile(t, filepath.Join(root, ".debrickedignore"), "**/*.tmp\n")
+
+	found := FindNearest(filepath.Join(root, "sub", "deeper"))
+	assert.Equal(t, filepath.Join(root, ".debrickedignore"), found)
+}
+
+func TestFindNearestPrefersClosestFile(t *testing.T) {
+	root := t.TempDir()
+	mustMkdirAll(t, filepath.Join(root, ".git"))
+	mustMkdirAll(t, filepath.Join(root, "sub"))
+	mustWriteFile(t, filepath.Join(root, ".debrickedignore"), "**/*.tmp\n")
+	mustWriteFile(t, filepath.Join(root, "sub", ".debrickedignore"), "**/*.log\n")
+
+	found := FindNearest(filepath.Join(root, "sub"))
+	assert.Equal(t, filepath.Join(root, "sub", ".debrickedignore"), found)
+}
+
+func mustMkdirAll(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatalf("failed to create %s: %s", path, err)
+	}
+}
+
+func mustWriteFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write %s: %s", path, err)
+	}
+}
@@ -0,0 +1,98 @@
+package debrickedignore
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const (
+	fileName = ".debrickedignore"
+	gitDir   = ".git"
+)
+
+// Source identifies where a rule in the merged, print-effective-exclusions
+// debug dump came from.
+type Source struct {
+	Rules
+	Origin string // path of the .debrickedignore file the rules were read from
+}
+
+// GlobalPath returns the path of the user-global .debrickedignore, i.e.
+// $XDG_CONFIG_HOME/debricked/ignore (or ~/.config/debricked/ignore).
+func GlobalPath() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "debricked", "ignore"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", "debricked", "ignore"), nil
+}
+
+// FindNearest walks upward from scanRoot looking for a .debrickedignore
+// file, stopping (inclusive) at the repository root, or the filesystem
+// root if scanRoot isn't inside a git working tree. It returns "" if none
+// is found.
+func FindNearest(scanRoot string) string {
+	dir, err := filepath.Abs(scanRoot)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		candidate := filepath.Join(dir, fileName)
+		if info, statErr := os.Stat(candidate); statErr == nil && !info.IsDir() {
+			return candidate
+		}
+
+		if _, statErr := os.Stat(filepath.Join(dir, gitDir)); statErr == nil {
+			return ""
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Load resolves the effective exclusion/inclusion rules for section,
+// merging the user-global .debrickedignore (lowest precedence of the two
+// file sources) with the nearest project .debrickedignore (highest), and
+// reports the path each layer came from for diagnostics.
+func Load(scanRoot string, section string) ([]Source, error) {
+	var sources []Source
+
+	if globalPath, err := GlobalPath(); err == nil {
+		if cfg, ok, err := loadFile(globalPath); err == nil && ok {
+			sources = append(sources, Source{Rules: cfg.For(section), Origin: globalPath})
+		}
+	}
+
+	if nearest := FindNearest(scanRoot); nearest != "" {
+		if cfg, ok, err := loadFile(nearest); err == nil && ok {
+			sources = append(sources, Source{Rules: cfg.For(section), Origin: nearest})
+		}
+	}
+
+	return sources, nil
+}
+
+func loadFile(path string) (*Config, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false, nil //nolint:nilerr // missing file is not an error, just "no rules here"
+	}
+	defer func() { _ = f.Close() }()
+
+	cfg, err := Parse(f)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return cfg, true, nil
+}
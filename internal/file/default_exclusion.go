@@ -1,6 +1,9 @@
 package file
 
-import "path/filepath"
+import (
+	"os"
+	"path/filepath"
+)
 
 func DefaultExclusions() []string {
 	return []string{
@@ -29,3 +32,13 @@ func DefaultExclusionsFingerprint() []string {
 
 	return output
 }
+
+// DefaultRespectGitignore reports whether DebrickedOptions.RespectGitignore
+// should default to true for rootPath, i.e. whether rootPath looks like a
+// git working tree. Scans outside a git repository have no .gitignore rules
+// to honor, so they default to false.
+func DefaultRespectGitignore(rootPath string) bool {
+	info, err := os.Stat(filepath.Join(rootPath, ".git"))
+
+	return err == nil && info != nil
+}
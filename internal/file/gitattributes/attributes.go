@@ -0,0 +1,133 @@
+// Package gitattributes parses .gitattributes files using the same
+// directory-scoped rule model as the gitignore package, and resolves the
+// effective attribute set for a given path.
+package gitattributes
+
+import (
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// AttrState describes how an attribute was set by a matching pattern.
+type AttrState int
+
+const (
+	// Unspecified means a prior, less specific rule's value should be
+	// dropped (the attribute reverts to unset), written as "!attr".
+	Unspecified AttrState = iota
+	// Set means the attribute is present with no explicit value ("attr").
+	Set
+	// Unset means the attribute is explicitly disabled ("-attr").
+	Unset
+	// ValueSet means the attribute carries an explicit value ("attr=value").
+	ValueSet
+)
+
+// Attribute is a single name/state pair parsed from a .gitattributes line.
+type Attribute struct {
+	Name  string
+	State AttrState
+	Value string
+}
+
+// Pattern is a single parsed .gitattributes line, scoped to the directory
+// it was declared in (its domain), same as gitignore.Pattern.
+type Pattern struct {
+	raw        string
+	domain     []string
+	anchored   bool
+	attributes []Attribute
+}
+
+// ParsePattern parses a single .gitattributes line declared in domain (the
+// slash-separated directory path, relative to the scan root, that the
+// owning file lives in). It returns nil for blank lines and comments.
+func ParsePattern(line string, domain []string) *Pattern {
+	line = strings.TrimRight(line, "\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	raw := fields[0]
+	anchored := strings.Contains(raw, "/")
+	raw = strings.TrimPrefix(raw, "/")
+
+	attrs := make([]Attribute, 0, len(fields)-1)
+	for _, f := range fields[1:] {
+		attrs = append(attrs, parseAttribute(f))
+	}
+
+	return &Pattern{raw: raw, domain: domain, anchored: anchored, attributes: attrs}
+}
+
+func parseAttribute(field string) Attribute {
+	switch {
+	case strings.HasPrefix(field, "-"):
+		return Attribute{Name: field[1:], State: Unset}
+	case strings.HasPrefix(field, "!"):
+		return Attribute{Name: field[1:], State: Unspecified}
+	case strings.Contains(field, "="):
+		parts := strings.SplitN(field, "=", 2)
+
+		return Attribute{Name: parts[0], State: ValueSet, Value: parts[1]}
+	default:
+		return Attribute{Name: field, State: Set}
+	}
+}
+
+// Match reports whether the pattern applies to path (slash-separated,
+// relative to the scan root).
+func (p *Pattern) Match(path []string) bool {
+	if len(path) < len(p.domain) {
+		return false
+	}
+	for i, d := range p.domain {
+		if path[i] != d {
+			return false
+		}
+	}
+
+	rel := path[len(p.domain):]
+	if len(rel) == 0 {
+		return false
+	}
+
+	if p.anchored {
+		ok, _ := doublestar.Match(p.raw, strings.Join(rel, "/"))
+
+		return ok
+	}
+
+	for i := range rel {
+		if ok, _ := doublestar.Match(p.raw, strings.Join(rel[i:], "/")); ok {
+			return true
+		}
+		if ok, _ := doublestar.Match(p.raw, rel[len(rel)-1]); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AttrValue renders an attribute's resolved state as a string: "true" for
+// Set, "false" for Unset, the explicit value for ValueSet, and "" for
+// Unspecified.
+func AttrValue(a Attribute) string {
+	switch a.State {
+	case Set:
+		return "true"
+	case Unset:
+		return "false"
+	case ValueSet:
+		return a.Value
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,67 @@
+package gitattributes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePattern(t *testing.T) {
+	p := ParsePattern("vendor/** linguist-vendored", nil)
+	assert.NotNil(t, p)
+	assert.Equal(t, "vendor/**", p.raw)
+	assert.Len(t, p.attributes, 1)
+	assert.Equal(t, Attribute{Name: "linguist-vendored", State: Set}, p.attributes[0])
+}
+
+func TestParsePatternValueAndNegation(t *testing.T) {
+	p := ParsePattern("**/*.pb.go linguist-generated=true -text !eol", nil)
+	assert.NotNil(t, p)
+	assert.Equal(t, []Attribute{
+		{Name: "linguist-generated", State: ValueSet, Value: "true"},
+		{Name: "text", State: Unset},
+		{Name: "eol", State: Unspecified},
+	}, p.attributes)
+}
+
+func TestMatcherAttributesFor(t *testing.T) {
+	patterns := []Pattern{
+		*ParsePattern("vendor/** linguist-vendored", nil),
+		*ParsePattern("vendor/keep/** -linguist-vendored", nil),
+	}
+	m := NewMatcher("/repo", patterns)
+
+	assert.Equal(t, map[string]string{"linguist-vendored": "true"}, m.AttributesFor(filepath.Join("/repo", "vendor", "pkg", "main.go")))
+	assert.Equal(t, map[string]string{"linguist-vendored": "false"}, m.AttributesFor(filepath.Join("/repo", "vendor", "keep", "main.go")))
+	assert.Empty(t, m.AttributesFor(filepath.Join("/repo", "src", "main.go")))
+}
+
+func TestLoadMatcherDirectoryScoping(t *testing.T) {
+	root := t.TempDir()
+	mustMkdirAll(t, filepath.Join(root, "docs", "generated"))
+	mustWriteFile(t, filepath.Join(root, ".gitattributes"), "**/*.pb.go linguist-generated=true\n")
+	mustWriteFile(t, filepath.Join(root, "docs", "generated", ".gitattributes"), "** linguist-documentation\n")
+
+	m, err := LoadMatcher(root)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "true", m.AttributesFor(filepath.Join(root, "api", "types.pb.go"))["linguist-generated"])
+	assert.Equal(t, "true", m.AttributesFor(filepath.Join(root, "docs", "generated", "index.html"))["linguist-documentation"])
+	assert.Empty(t, m.AttributesFor(filepath.Join(root, "docs", "index.html")))
+}
+
+func mustMkdirAll(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatalf("failed to create %s: %s", path, err)
+	}
+}
+
+func mustWriteFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write %s: %s", path, err)
+	}
+}
@@ -0,0 +1,82 @@
+package gitattributes
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+)
+
+const (
+	attributesFileName = ".gitattributes"
+	gitDirName         = ".git"
+	infoAttributesPath = "info/attributes"
+)
+
+// LoadMatcher walks downward from root collecting every .gitattributes
+// file (plus .git/info/attributes, if present), attributing each pattern
+// to the directory it was declared in.
+func LoadMatcher(root string) (*Matcher, error) {
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		resolvedRoot = root
+	}
+	resolvedRoot, err = filepath.Abs(resolvedRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var patterns []Pattern
+
+	infoAttrs := filepath.Join(resolvedRoot, gitDirName, infoAttributesPath)
+	if ps, err := readPatternFile(infoAttrs, nil); err == nil {
+		patterns = append(patterns, ps...)
+	}
+
+	err = filepath.Walk(resolvedRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return filepath.SkipDir
+		}
+		if info.IsDir() && info.Name() == gitDirName {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || info.Name() != attributesFileName {
+			return nil
+		}
+
+		domain, ok := splitPath(resolvedRoot, filepath.Dir(path))
+		if !ok {
+			return nil
+		}
+
+		ps, err := readPatternFile(path, domain)
+		if err != nil {
+			return nil
+		}
+		patterns = append(patterns, ps...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMatcher(resolvedRoot, patterns), nil
+}
+
+func readPatternFile(path string, domain []string) ([]Pattern, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var patterns []Pattern
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if p := ParsePattern(scanner.Text(), domain); p != nil {
+			patterns = append(patterns, *p)
+		}
+	}
+
+	return patterns, scanner.Err()
+}
@@ -0,0 +1,64 @@
+package gitattributes
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Matcher resolves the effective .gitattributes attribute set for paths
+// under root.
+type Matcher struct {
+	root     string
+	patterns []Pattern
+}
+
+// NewMatcher builds a Matcher for root out of an already-parsed, ordered
+// pattern stack (shallowest domain first, each file's lines in file order).
+func NewMatcher(root string, patterns []Pattern) *Matcher {
+	return &Matcher{root: root, patterns: patterns}
+}
+
+// AttributesFor resolves the effective attribute map for path: every
+// pattern matching path contributes its attributes, later (more specific,
+// or later-declared-at-the-same-domain) patterns winning per attribute
+// name, with "!attr" reverting to unset rather than just not-overriding.
+func (m *Matcher) AttributesFor(path string) map[string]string {
+	segments, ok := splitPath(m.root, path)
+	result := map[string]string{}
+	if !ok {
+		return result
+	}
+
+	for i := range m.patterns {
+		p := &m.patterns[i]
+		if !p.Match(segments) {
+			continue
+		}
+		for _, attr := range p.attributes {
+			if attr.State == Unspecified {
+				delete(result, attr.Name)
+
+				continue
+			}
+			result[attr.Name] = AttrValue(attr)
+		}
+	}
+
+	return result
+}
+
+func splitPath(root, path string) ([]string, bool) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return nil, false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." {
+		return []string{}, true
+	}
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return nil, false
+	}
+
+	return strings.Split(rel, "/"), true
+}
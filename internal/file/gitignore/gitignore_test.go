@@ -0,0 +1,131 @@
+package gitignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePattern(t *testing.T) {
+	cases := []struct {
+		name     string
+		line     string
+		expected *Pattern
+	}{
+		{
+			name:     "Comment",
+			line:     "# a comment",
+			expected: nil,
+		},
+		{
+			name:     "Blank",
+			line:     "   ",
+			expected: nil,
+		},
+		{
+			name:     "EscapedHash",
+			line:     "\\#notacomment",
+			expected: &Pattern{raw: "#notacomment"},
+		},
+		{
+			name:     "Negation",
+			line:     "!keep.txt",
+			expected: &Pattern{raw: "keep.txt", negate: true},
+		},
+		{
+			name:     "DirOnly",
+			line:     "build/",
+			expected: &Pattern{raw: "build", dirOnly: true},
+		},
+		{
+			name:     "Anchored",
+			line:     "/root-only.txt",
+			expected: &Pattern{raw: "root-only.txt", anchored: true},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := ParsePattern(c.line, nil)
+			if c.expected == nil {
+				assert.Nil(t, p)
+
+				return
+			}
+			assert.Equal(t, c.expected.raw, p.raw)
+			assert.Equal(t, c.expected.negate, p.negate)
+			assert.Equal(t, c.expected.dirOnly, p.dirOnly)
+			assert.Equal(t, c.expected.anchored, p.anchored)
+		})
+	}
+}
+
+func TestMatcherNegationWins(t *testing.T) {
+	patterns := []Pattern{
+		*ParsePattern("*.log", nil),
+		*ParsePattern("!important.log", nil),
+	}
+	m := NewMatcher("/repo", patterns)
+
+	assert.True(t, m.Match("/repo/debug.log", false))
+	assert.False(t, m.Match("/repo/important.log", false))
+}
+
+func TestMatcherDirectoryScoping(t *testing.T) {
+	patterns := []Pattern{
+		*ParsePattern("/build/", []string{"sub"}),
+	}
+	m := NewMatcher("/repo", patterns)
+
+	assert.True(t, m.Match("/repo/sub/build", true))
+	assert.False(t, m.Match("/repo/build", true), "pattern is scoped to sub/ and must not apply at the repo root")
+	assert.False(t, m.Match("/repo/sub/build", false), "pattern is directory-only")
+}
+
+func TestMatcherPathOutsideRootIsIgnored(t *testing.T) {
+	m := NewMatcher("/repo", []Pattern{*ParsePattern("*.log", nil)})
+
+	assert.False(t, m.Match("/elsewhere/debug.log", false))
+}
+
+func TestLoadMatcherWalksUpAndDown(t *testing.T) {
+	root := t.TempDir()
+	mustMkdirAll(t, filepath.Join(root, ".git"))
+	mustMkdirAll(t, filepath.Join(root, "sub"))
+	mustWriteFile(t, filepath.Join(root, ".gitignore"), "*.log\n!keep.log\n")
+	mustWriteFile(t, filepath.Join(root, "sub", ".gitignore"), "/local-only.txt\n")
+
+	m, err := LoadMatcher(filepath.Join(root, "sub"))
+	assert.NoError(t, err)
+
+	assert.True(t, m.Match(filepath.Join(root, "debug.log"), false))
+	assert.False(t, m.Match(filepath.Join(root, "keep.log"), false))
+	assert.True(t, m.Match(filepath.Join(root, "sub", "local-only.txt"), false))
+	assert.False(t, m.Match(filepath.Join(root, "local-only.txt"), false), "pattern declared in sub/ must not apply at the repo root")
+}
+
+func TestLoadMatcherCRLFLineEndings(t *testing.T) {
+	root := t.TempDir()
+	mustMkdirAll(t, filepath.Join(root, ".git"))
+	mustWriteFile(t, filepath.Join(root, ".gitignore"), "*.tmp\r\n")
+
+	m, err := LoadMatcher(root)
+	assert.NoError(t, err)
+	assert.True(t, m.Match(filepath.Join(root, "scratch.tmp"), false))
+}
+
+func mustMkdirAll(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatalf("failed to create %s: %s", path, err)
+	}
+}
+
+func mustWriteFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write %s: %s", path, err)
+	}
+}
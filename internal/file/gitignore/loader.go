@@ -0,0 +1,153 @@
+package gitignore
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+)
+
+const (
+	gitDir          = ".git"
+	gitignoreFile   = ".gitignore"
+	gitExcludeFile  = "info/exclude"
+	xdgConfigEnvVar = "XDG_CONFIG_HOME"
+)
+
+// LoadMatcher builds a Matcher for scanRoot by walking upward to locate the
+// repository root (and, from there, the global excludesfile and
+// .git/info/exclude), then walking downward from the repository root
+// collecting every .gitignore file. Symlinked worktrees are resolved before
+// walking so patterns are always attributed to their real containing
+// directory.
+func LoadMatcher(scanRoot string) (*Matcher, error) {
+	resolvedRoot, err := filepath.EvalSymlinks(scanRoot)
+	if err != nil {
+		resolvedRoot = scanRoot
+	}
+	resolvedRoot, err = filepath.Abs(resolvedRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	repoRoot := findRepoRoot(resolvedRoot)
+
+	var patterns []Pattern
+
+	if global, err := globalPatterns(); err == nil {
+		patterns = append(patterns, global...)
+	}
+
+	if repoRoot != "" {
+		excludeFile := filepath.Join(repoRoot, gitDir, gitExcludeFile)
+		if ps, err := readPatternFile(excludeFile, nil); err == nil {
+			patterns = append(patterns, ps...)
+		}
+	}
+
+	walkRoot := repoRoot
+	if walkRoot == "" {
+		walkRoot = resolvedRoot
+	}
+
+	downward, err := collectGitignores(walkRoot)
+	if err != nil {
+		return nil, err
+	}
+	patterns = append(patterns, downward...)
+
+	return NewMatcher(walkRoot, patterns), nil
+}
+
+// findRepoRoot walks upward from dir looking for a .git entry, returning ""
+// if none is found (e.g. the scan root isn't inside a git working tree).
+func findRepoRoot(dir string) string {
+	for {
+		if info, err := os.Stat(filepath.Join(dir, gitDir)); err == nil && info != nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// collectGitignores walks downward from root, parsing every .gitignore file
+// it finds and attributing each pattern to the directory it was declared
+// in, ordered shallowest-first so deeper, more specific rules are appended
+// last (and therefore win ties in Matcher.Match).
+func collectGitignores(root string) ([]Pattern, error) {
+	var patterns []Pattern
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Unreadable subtrees are skipped rather than failing the scan.
+			return filepath.SkipDir
+		}
+		if info.IsDir() && info.Name() == gitDir {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || info.Name() != gitignoreFile {
+			return nil
+		}
+
+		domain, ok := splitPath(root, filepath.Dir(path))
+		if !ok {
+			return nil
+		}
+
+		ps, err := readPatternFile(path, domain)
+		if err != nil {
+			return nil
+		}
+		patterns = append(patterns, ps...)
+
+		return nil
+	})
+
+	return patterns, err
+}
+
+func readPatternFile(path string, domain []string) ([]Pattern, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var patterns []Pattern
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if p := ParsePattern(scanner.Text(), domain); p != nil {
+			patterns = append(patterns, *p)
+		}
+	}
+
+	return patterns, scanner.Err()
+}
+
+// globalPatterns reads the user's global excludesfile from git's default
+// location, $XDG_CONFIG_HOME/git/ignore (or ~/.config/git/ignore). A
+// core.excludesfile setting in the git config is not read.
+func globalPatterns() ([]Pattern, error) {
+	path, err := globalExcludesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return readPatternFile(path, nil)
+}
+
+func globalExcludesPath() (string, error) {
+	if xdg := os.Getenv(xdgConfigEnvVar); xdg != "" {
+		return filepath.Join(xdg, "git", "ignore"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", "git", "ignore"), nil
+}
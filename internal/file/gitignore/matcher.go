@@ -0,0 +1,36 @@
+package gitignore
+
+// Matcher holds an ordered stack of Patterns and resolves a path against
+// all of them, letting the last matching rule win. That makes a trailing
+// negation re-include a path excluded by an earlier, broader pattern.
+type Matcher struct {
+	root     string
+	patterns []Pattern
+}
+
+// NewMatcher builds a Matcher for root out of an already-parsed, ordered
+// pattern stack (shallowest domain first, each file's lines in file order).
+func NewMatcher(root string, patterns []Pattern) *Matcher {
+	return &Matcher{root: root, patterns: patterns}
+}
+
+// Match reports whether path is excluded by the matcher's patterns. path
+// is resolved against root with filepath.Rel, so it must be absolute when
+// root is (as LoadMatcher's root always is); paths outside root, or that
+// cannot be made relative to it, are never matched. isDir must be true
+// when path refers to a directory.
+func (m *Matcher) Match(path string, isDir bool) bool {
+	segments, ok := splitPath(m.root, path)
+	if !ok || len(segments) == 0 {
+		return false
+	}
+
+	result := NoMatch
+	for i := range m.patterns {
+		if r := m.patterns[i].Match(segments, isDir); r != NoMatch {
+			result = r
+		}
+	}
+
+	return result == Exclude
+}
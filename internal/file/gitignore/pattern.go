@@ -0,0 +1,158 @@
+// Package gitignore implements a gitignore-compatible matcher for the file
+// package. It mirrors the directory-scoped pattern model used by git itself
+// (and by go-git's plumbing/format/gitignore): every parsed line is kept
+// together with the directory it was declared in, and later, more specific
+// rules win over earlier, broader ones.
+package gitignore
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// MatchResult is the outcome of testing a single Pattern against a path.
+type MatchResult int
+
+const (
+	// NoMatch means the pattern has no opinion about the path.
+	NoMatch MatchResult = iota
+	// Exclude means the pattern matched and the path should be ignored.
+	Exclude
+	// Include means the pattern matched a negated ("!") rule and the path
+	// should be re-included, overriding a previous Exclude.
+	Include
+)
+
+// Pattern is a single parsed gitignore line, scoped to the directory it was
+// declared in (its domain). An empty domain means the pattern was declared
+// at the root of the scan (e.g. the global excludesfile or .git/info/exclude).
+type Pattern struct {
+	raw      string
+	domain   []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// ParsePattern parses a single gitignore line declared in the given domain
+// (the slash-separated directory path, relative to the scan root, that the
+// owning file lives in). It returns nil for blank lines and comments.
+func ParsePattern(line string, domain []string) *Pattern {
+	line = strings.TrimRight(line, "\r")
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	// Escaped "#" and "!" are literal, not comment/negation markers.
+	if strings.HasPrefix(line, "\\#") || strings.HasPrefix(line, "\\!") {
+		line = line[1:]
+	} else if strings.HasPrefix(line, "!") {
+		line = line[1:]
+
+		return parseBody(line, domain, true)
+	}
+
+	return parseBody(line, domain, false)
+}
+
+func parseBody(line string, domain []string, negate bool) *Pattern {
+	// Trailing spaces are trimmed unless escaped with a backslash.
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = strings.TrimSuffix(line, " ")
+	}
+	line = strings.ReplaceAll(line, "\\ ", " ")
+
+	if line == "" {
+		return nil
+	}
+
+	dirOnly := strings.HasSuffix(line, "/")
+	if dirOnly {
+		line = strings.TrimSuffix(line, "/")
+	}
+
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	return &Pattern{
+		raw:      line,
+		domain:   domain,
+		negate:   negate,
+		dirOnly:  dirOnly,
+		anchored: anchored,
+	}
+}
+
+// Match reports whether the pattern applies to path (slash-separated,
+// relative to the scan root). isDir must be true when path is a directory.
+func (p *Pattern) Match(path []string, isDir bool) MatchResult {
+	if len(path) < len(p.domain) {
+		return NoMatch
+	}
+
+	for i, d := range p.domain {
+		if path[i] != d {
+			return NoMatch
+		}
+	}
+
+	rel := path[len(p.domain):]
+	if len(rel) == 0 {
+		return NoMatch
+	}
+
+	if p.dirOnly && !isDir {
+		return NoMatch
+	}
+
+	matched := false
+	if p.anchored {
+		matched, _ = doublestar.Match(p.raw, strings.Join(rel, "/"))
+	} else {
+		// An unanchored pattern may match at any depth below its domain.
+		for i := range rel {
+			if ok, _ := doublestar.Match(p.raw, strings.Join(rel[i:], "/")); ok {
+				matched = true
+
+				break
+			}
+			if ok, _ := doublestar.Match(p.raw, rel[len(rel)-1]); ok {
+				matched = true
+
+				break
+			}
+		}
+	}
+
+	if !matched {
+		return NoMatch
+	}
+
+	if p.negate {
+		return Include
+	}
+
+	return Exclude
+}
+
+// splitPath turns an OS path into scan-root-relative, slash-separated
+// segments. Paths outside root (i.e. where Rel fails or escapes via "..")
+// yield ok=false so callers can treat them as unmatched rather than error.
+func splitPath(root, path string) (segments []string, ok bool) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return nil, false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." {
+		return []string{}, true
+	}
+	if strings.HasPrefix(rel, "../") || rel == ".." {
+		return nil, false
+	}
+
+	return strings.Split(rel, "/"), true
+}
@@ -0,0 +1,187 @@
+// Package fingerprint hashes files for identification against the
+// Debricked knowledge base.
+package fingerprint
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/file/gitattributes"
+	"github.com/debricked/cli/internal/file/gitignore"
+)
+
+// OutputFileNameFingerprints is the default file fingerprint output is
+// written to.
+const OutputFileNameFingerprints = "debricked-fingerprints.txt"
+
+// DefaultHashAlgorithm is the algorithm used when DebrickedOptions.HashAlgorithms
+// is empty, and the one that triggers the legacy v1 output format in
+// Fingerprints.ToFile.
+const DefaultHashAlgorithm = "md5"
+
+// SupportedHashAlgorithms lists the digests FingerprintFiles can compute.
+var SupportedHashAlgorithms = []string{"md5", "sha1", "sha256", "sha512"}
+
+// ErrUnsupportedHashAlgorithm is returned when DebrickedOptions.HashAlgorithms
+// contains a value outside SupportedHashAlgorithms.
+var ErrUnsupportedHashAlgorithm = errors.New("unsupported hash algorithm")
+
+// IFingerprint fingerprints files for identification.
+type IFingerprint interface {
+	FingerprintFiles(options DebrickedOptions) (*Fingerprints, error)
+}
+
+// DebrickedOptions configures a fingerprinting run.
+type DebrickedOptions struct {
+	Path                         string
+	Exclusions                   []string
+	Inclusions                   []string
+	MinFingerprintContentLength  int
+	FingerprintCompressedContent bool
+	Regenerate                   bool
+	RespectGitignore             bool
+	AttributeExclusions          []string
+	HashAlgorithms               []string
+	SignOutput                   bool
+	SignKeyPath                  string
+	SignMode                     string
+}
+
+// DebrickedFingerprint is the default IFingerprint implementation.
+type DebrickedFingerprint struct{}
+
+// NewDebrickedFingerprint creates a DebrickedFingerprint.
+func NewDebrickedFingerprint() *DebrickedFingerprint {
+	return &DebrickedFingerprint{}
+}
+
+// DefaultExclusionsFingerprint returns the fingerprinting package's default
+// path exclusions.
+func DefaultExclusionsFingerprint() []string {
+	return file.DefaultExclusionsFingerprint()
+}
+
+// DefaultInclusionsFingerprint returns the fingerprinting package's default
+// path inclusions, which override exclusions.
+func DefaultInclusionsFingerprint() []string {
+	return []string{}
+}
+
+// FingerprintFiles walks options.Path, hashing every file that isn't
+// excluded by options.Exclusions/Inclusions, a matching .gitignore rule
+// (when options.RespectGitignore is set), or a matching .gitattributes
+// exclusion (when options.AttributeExclusions is set), and computes every
+// digest in options.HashAlgorithms.
+func (d *DebrickedFingerprint) FingerprintFiles(options DebrickedOptions) (*Fingerprints, error) {
+	algorithms := options.HashAlgorithms
+	if len(algorithms) == 0 {
+		algorithms = []string{DefaultHashAlgorithm}
+	}
+	for _, algorithm := range algorithms {
+		if !isSupportedHashAlgorithm(algorithm) {
+			return nil, ErrUnsupportedHashAlgorithm
+		}
+	}
+
+	root := options.Path
+	if root == "" {
+		root = "."
+	}
+
+	var gitignoreMatcher *gitignore.Matcher
+	if options.RespectGitignore {
+		gitignoreMatcher, _ = gitignore.LoadMatcher(root)
+	}
+
+	var attributesMatcher *gitattributes.Matcher
+	if len(options.AttributeExclusions) > 0 {
+		attributesMatcher, _ = gitattributes.LoadMatcher(root)
+	}
+
+	fingerprints := &Fingerprints{HashAlgorithms: algorithms}
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Both matchers resolve paths against an absolute root, so a
+		// relative walk path (root "." or a relative options.Path) is made
+		// absolute before matching.
+		matchPath := path
+		if absPath, absErr := filepath.Abs(path); absErr == nil {
+			matchPath = absPath
+		}
+
+		if info.IsDir() {
+			if gitignoreMatcher != nil && path != root && gitignoreMatcher.Match(matchPath, true) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if excluded(options.Exclusions, options.Inclusions, path) {
+			return nil
+		}
+
+		if gitignoreMatcher != nil && gitignoreMatcher.Match(matchPath, false) {
+			return nil
+		}
+
+		if attributesMatcher != nil {
+			attrs := attributesMatcher.AttributesFor(matchPath)
+			if file.ExcludedByAttributes(attrs, options.AttributeExclusions) {
+				return nil
+			}
+		}
+
+		if info.Size() < int64(options.MinFingerprintContentLength) {
+			return nil
+		}
+
+		fingerprint, ferrFingerprint := fingerprintFile(path, info.Size(), algorithms)
+		if ferrFingerprint != nil {
+			return nil
+		}
+		fingerprints.Content = append(fingerprints.Content, fingerprint)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fingerprints, nil
+}
+
+func isSupportedHashAlgorithm(algorithm string) bool {
+	for _, supported := range SupportedHashAlgorithms {
+		if algorithm == supported {
+			return true
+		}
+	}
+
+	return false
+}
+
+func excluded(exclusions []string, inclusions []string, path string) bool {
+	slashPath := filepath.ToSlash(path)
+
+	for _, inclusion := range inclusions {
+		if ok, _ := doublestar.Match(filepath.ToSlash(inclusion), slashPath); ok {
+			return false
+		}
+	}
+
+	for _, exclusion := range exclusions {
+		if ok, _ := doublestar.Match(filepath.ToSlash(exclusion), slashPath); ok {
+			return true
+		}
+	}
+
+	return false
+}
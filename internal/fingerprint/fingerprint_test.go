@@ -0,0 +1,74 @@
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprintFilesComputesRequestedAlgorithms(t *testing.T) {
+	root := t.TempDir()
+	mustWriteFile(t, filepath.Join(root, "main.go"), "package main\n")
+
+	f := NewDebrickedFingerprint()
+	fingerprints, err := f.FingerprintFiles(DebrickedOptions{
+		Path:           root,
+		HashAlgorithms: []string{"sha256", "md5"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, fingerprints.Content, 1)
+
+	fp := fingerprints.Content[0]
+	assert.Contains(t, fp.Hashes, "sha256")
+	assert.Contains(t, fp.Hashes, "md5")
+	assert.Len(t, fp.Hashes["sha256"], 64)
+	assert.Len(t, fp.Hashes["md5"], 32)
+}
+
+func TestFingerprintFilesRejectsUnsupportedAlgorithm(t *testing.T) {
+	f := NewDebrickedFingerprint()
+	_, err := f.FingerprintFiles(DebrickedOptions{Path: t.TempDir(), HashAlgorithms: []string{"sha3"}})
+	assert.ErrorIs(t, err, ErrUnsupportedHashAlgorithm)
+}
+
+func TestFingerprintsToFileLegacyFormat(t *testing.T) {
+	fingerprints := &Fingerprints{
+		HashAlgorithms: []string{DefaultHashAlgorithm},
+		Content: []Fingerprint{
+			{Path: "a.go", Size: 10, Hashes: map[string]string{"md5": "deadbeef"}},
+		},
+	}
+
+	out := filepath.Join(t.TempDir(), "fingerprints.txt")
+	assert.NoError(t, fingerprints.ToFile(out))
+
+	content, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Equal(t, "deadbeef 10 a.go\n", string(content))
+}
+
+func TestFingerprintsToFileVersionedFormat(t *testing.T) {
+	fingerprints := &Fingerprints{
+		HashAlgorithms: []string{"sha256"},
+		Content: []Fingerprint{
+			{Path: "a.go", Size: 10, Hashes: map[string]string{"sha256": "cafef00d"}},
+		},
+	}
+
+	out := filepath.Join(t.TempDir(), "fingerprints.txt")
+	assert.NoError(t, fingerprints.ToFile(out))
+
+	content, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "v2 sha256:cafef00d 10 a.go"))
+}
+
+func mustWriteFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write %s: %s", path, err)
+	}
+}
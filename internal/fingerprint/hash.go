@@ -0,0 +1,61 @@
+package fingerprint
+
+import (
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+)
+
+// Fingerprint is a single file's digests, one per requested hash algorithm.
+type Fingerprint struct {
+	Path   string
+	Size   int64
+	Hashes map[string]string
+}
+
+func fingerprintFile(path string, size int64, algorithms []string) (Fingerprint, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Fingerprint{}, err
+	}
+	defer func() { _ = f.Close() }()
+
+	hashes := make(map[string]hash.Hash, len(algorithms))
+	writers := make([]io.Writer, 0, len(algorithms))
+	for _, algorithm := range algorithms {
+		h := newHash(algorithm)
+		hashes[algorithm] = h
+		writers = append(writers, h)
+	}
+
+	// A single read feeds every requested digest via io.MultiWriter, so
+	// multi-GB fingerprint runs don't re-read each file once per algorithm.
+	if _, err := io.Copy(io.MultiWriter(writers...), f); err != nil {
+		return Fingerprint{}, err
+	}
+
+	digests := make(map[string]string, len(algorithms))
+	for algorithm, h := range hashes {
+		digests[algorithm] = hex.EncodeToString(h.Sum(nil))
+	}
+
+	return Fingerprint{Path: path, Size: size, Hashes: digests}, nil
+}
+
+func newHash(algorithm string) hash.Hash {
+	switch algorithm {
+	case "sha1":
+		return sha1.New() //nolint:gosec
+	case "sha256":
+		return sha256.New()
+	case "sha512":
+		return sha512.New()
+	default:
+		return md5.New() //nolint:gosec
+	}
+}
@@ -0,0 +1,38 @@
+package fingerprint
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Fingerprints is the result of a fingerprinting run.
+type Fingerprints struct {
+	Content        []Fingerprint
+	HashAlgorithms []string
+}
+
+// ToFile writes fingerprints to path. When HashAlgorithms is exactly
+// [DefaultHashAlgorithm] it writes the legacy v1 format ("<hex> <size>
+// <path>") so existing downstream tooling keeps working; otherwise it
+// writes one versioned line per file per algorithm ("v2 <algo>:<hex>
+// <size> <path>") so multiple digests can coexist unambiguously.
+func (f *Fingerprints) ToFile(path string) error {
+	var b strings.Builder
+
+	legacy := len(f.HashAlgorithms) == 1 && f.HashAlgorithms[0] == DefaultHashAlgorithm
+
+	for _, fp := range f.Content {
+		if legacy {
+			fmt.Fprintf(&b, "%s %d %s\n", fp.Hashes[DefaultHashAlgorithm], fp.Size, fp.Path)
+
+			continue
+		}
+
+		for _, algorithm := range f.HashAlgorithms {
+			fmt.Fprintf(&b, "v2 %s:%s %d %s\n", algorithm, fp.Hashes[algorithm], fp.Size, fp.Path)
+		}
+	}
+
+	return os.WriteFile(path, []byte(b.String()), 0600)
+}
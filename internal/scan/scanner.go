@@ -15,11 +15,13 @@ import (
 	"github.com/debricked/cli/internal/client"
 	"github.com/debricked/cli/internal/debug"
 	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/file/debrickedignore"
 	"github.com/debricked/cli/internal/fingerprint"
 	"github.com/debricked/cli/internal/git"
 	"github.com/debricked/cli/internal/io"
 	"github.com/debricked/cli/internal/report/sbom"
 	"github.com/debricked/cli/internal/resolution"
+	"github.com/debricked/cli/internal/sign"
 	"github.com/debricked/cli/internal/tui"
 	"github.com/debricked/cli/internal/upload"
 	"github.com/fatih/color"
@@ -75,6 +77,13 @@ type DebrickedOptions struct {
 	TagCommitAsRelease          bool
 	Experimental                bool
 	Version                     string
+	HashAlgorithms              []string
+	PrintEffectiveExclusions    bool
+	SignOutput                  bool
+	SignKeyPath                 string
+	SignMode                    string
+	RespectGitignore            bool
+	AttributeExclusions         []string
 }
 
 func NewDebrickedScanner(
@@ -114,6 +123,14 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 		return err
 	}
 
+	if dOptions.PrintEffectiveExclusions {
+		if err := PrintEffectiveExclusions(dOptions); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
 	debug.Log("Setting up git objects...", dOptions.Debug)
 	gitMetaObject, err := git.NewMetaObject(
 		dOptions.Path,
@@ -140,7 +157,9 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 		return nil
 	}
 
-	WriteApiReplyToJsonFile(dOptions, result)
+	if err := WriteApiReplyToJsonFile(dOptions, result); err != nil {
+		return err
+	}
 
 	fmt.Printf("\n%d vulnerabilities found\n", result.VulnerabilitiesFound)
 	fmt.Println("")
@@ -180,12 +199,16 @@ func (dScanner *DebrickedScanner) scanReportSBOM(options DebrickedOptions, detai
 }
 
 func (dScanner *DebrickedScanner) scanResolve(options DebrickedOptions) error {
+	exclusions, inclusions, _, err := mergeDebrickedIgnoreRules(options.Path, debrickedignore.SectionResolve, options.Exclusions, options.Inclusions)
+	if err != nil {
+		return err
+	}
 	resolveOptions := resolution.DebrickedOptions{
 		Path:         options.Path,
 		Verbose:      options.Verbose,
 		Regenerate:   options.Regenerate,
-		Exclusions:   options.Exclusions,
-		Inclusions:   options.Inclusions,
+		Exclusions:   exclusions,
+		Inclusions:   inclusions,
 		NpmPreferred: options.NpmPreferred,
 	}
 	if options.Resolve {
@@ -204,22 +227,36 @@ func (dScanner *DebrickedScanner) scanFingerprint(options DebrickedOptions) erro
 
 			return nil
 		}
+		exclusions, inclusions, _, err := mergeDebrickedIgnoreRules(options.Path, debrickedignore.SectionFingerprint, options.Exclusions, options.Inclusions)
+		if err != nil {
+			return err
+		}
 		fingerprints, err := dScanner.fingerprint.FingerprintFiles(
 			fingerprint.DebrickedOptions{
 				Path:                         options.Path,
-				Exclusions:                   append(options.Exclusions, fingerprint.DefaultExclusionsFingerprint()...),
-				Inclusions:                   append(options.Inclusions, fingerprint.DefaultInclusionsFingerprint()...),
+				Exclusions:                   append(exclusions, fingerprint.DefaultExclusionsFingerprint()...),
+				Inclusions:                   append(inclusions, fingerprint.DefaultInclusionsFingerprint()...),
 				MinFingerprintContentLength:  options.MinFingerprintContentLength,
 				FingerprintCompressedContent: false,
 				Regenerate:                   options.Regenerate > 0,
+				HashAlgorithms:               options.HashAlgorithms,
+				RespectGitignore:             options.RespectGitignore,
+				AttributeExclusions:          append(options.AttributeExclusions, file.DefaultAttributeExclusions()...),
 			},
 		)
 		if err != nil {
 			return err
 		}
 		err = fingerprints.ToFile(fingerprint.OutputFileNameFingerprints)
+		if err != nil {
+			return err
+		}
 
-		return err
+		if options.SignOutput {
+			return sign.SignFile(sign.Mode(options.SignMode), sign.KeyConfig{KeyPath: options.SignKeyPath}, fingerprint.OutputFileNameFingerprints)
+		}
+
+		return nil
 	}
 
 	return nil
@@ -265,13 +302,18 @@ func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject g
 	}
 
 	debug.Log("Matching groups...", options.Debug)
+	exclusions, inclusions, _, err := mergeDebrickedIgnoreRules(options.Path, debrickedignore.SectionScan, options.Exclusions, options.Inclusions)
+	if err != nil {
+		return nil, err
+	}
 	fileGroups, err := dScanner.finder.GetGroups(
 		file.DebrickedOptions{
-			RootPath:     options.Path,
-			Exclusions:   options.Exclusions,
-			Inclusions:   options.Inclusions,
-			LockFileOnly: false,
-			Strictness:   file.StrictAll,
+			RootPath:         options.Path,
+			Exclusions:       exclusions,
+			Inclusions:       inclusions,
+			LockFileOnly:     false,
+			Strictness:       file.StrictAll,
+			RespectGitignore: options.RespectGitignore,
 		},
 	)
 	if err != nil {
@@ -285,7 +327,7 @@ func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject g
 		IntegrationsName:       options.IntegrationName,
 		CallGraphUploadTimeout: options.CallGraphUploadTimeout,
 		VersionHint:            options.VersionHint,
-		DebrickedConfig:        dScanner.getDebrickedConfig(options.Path, options.Exclusions, options.Inclusions),
+		DebrickedConfig:        dScanner.getDebrickedConfig(options.Path, exclusions, inclusions),
 		TagCommitAsRelease:     options.TagCommitAsRelease,
 		Experimental:           options.Experimental,
 	}
@@ -373,9 +415,82 @@ func MapEnvToOptions(o *DebrickedOptions, env env.Env) {
 	}
 }
 
-func WriteApiReplyToJsonFile(options DebrickedOptions, result *upload.UploadResult) {
+func WriteApiReplyToJsonFile(options DebrickedOptions, result *upload.UploadResult) error {
 	if options.JsonFilePath != "" {
-		file, _ := json.MarshalIndent(result, "", " ")
-		_ = os.WriteFile(options.JsonFilePath, file, 0600)
+		file, err := json.MarshalIndent(result, "", " ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(options.JsonFilePath, file, 0600); err != nil {
+			return err
+		}
+
+		if options.SignOutput {
+			return sign.SignFile(sign.Mode(options.SignMode), sign.KeyConfig{KeyPath: options.SignKeyPath}, options.JsonFilePath)
+		}
+	}
+
+	return nil
+}
+
+// mergeDebrickedIgnoreRules appends the nearest .debrickedignore's rules for
+// section onto exclusions/inclusions, on top of whatever --exclusion flags
+// and DEBRICKED_EXCLUSIONS already set. Each subsystem (resolve, fingerprint,
+// scan) calls this with its own section, so a rule scoped to e.g.
+// [fingerprint] only ever affects fingerprinting. It returns the merged
+// lists plus the sources it merged in, lowest precedence first, for
+// PrintEffectiveExclusions to report.
+func mergeDebrickedIgnoreRules(path string, section string, exclusions []string, inclusions []string) ([]string, []string, []debrickedignore.Source, error) {
+	root := path
+	if root == "" {
+		root = "."
+	}
+
+	sources, err := debrickedignore.Load(root, section)
+	if err != nil {
+		return nil, nil, nil, err
 	}
+
+	for _, source := range sources {
+		exclusions = append(exclusions, source.Exclusions...)
+		inclusions = append(inclusions, source.Inclusions...)
+	}
+
+	return exclusions, inclusions, sources, nil
+}
+
+// PrintEffectiveExclusions dumps the exclusion/inclusion list each subsystem
+// ends up using, annotated with where each .debrickedignore section came
+// from, for --print-effective-exclusions.
+func PrintEffectiveExclusions(o DebrickedOptions) error {
+	fmt.Println("Effective exclusions (CLI flags / DEBRICKED_EXCLUSIONS / defaults):")
+	for _, exclusion := range o.Exclusions {
+		fmt.Printf("  %s\n", exclusion)
+	}
+
+	sections := []struct {
+		name    string
+		section string
+	}{
+		{"scan", debrickedignore.SectionScan},
+		{"resolve", debrickedignore.SectionResolve},
+		{"fingerprint", debrickedignore.SectionFingerprint},
+	}
+	for _, s := range sections {
+		_, _, sources, err := mergeDebrickedIgnoreRules(o.Path, s.section, nil, nil)
+		if err != nil {
+			return err
+		}
+		for _, source := range sources {
+			fmt.Printf("Effective [%s] exclusions from %s:\n", s.name, source.Origin)
+			for _, exclusion := range source.Exclusions {
+				fmt.Printf("  %s\n", exclusion)
+			}
+			for _, inclusion := range source.Inclusions {
+				fmt.Printf("  !%s\n", inclusion)
+			}
+		}
+	}
+
+	return nil
 }
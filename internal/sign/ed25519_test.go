@@ -0,0 +1,54 @@
+package sign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEd25519SignAndVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "key")
+	pubPath := filepath.Join(dir, "key.pub")
+	assert.NoError(t, os.WriteFile(keyPath, priv, 0600))
+	assert.NoError(t, os.WriteFile(pubPath, pub, 0600))
+
+	signer := &Ed25519Signer{KeyPath: keyPath}
+	var sigBuf bytes.Buffer
+	assert.NoError(t, signer.Sign(strings.NewReader("hello fingerprint file"), &sigBuf))
+
+	verifier := &Ed25519Verifier{KeyringPath: pubPath}
+	assert.NoError(t, verifier.Verify(strings.NewReader("hello fingerprint file"), bytes.NewReader(sigBuf.Bytes())))
+}
+
+func TestEd25519VerifyRejectsTamperedContent(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "key")
+	pubPath := filepath.Join(dir, "key.pub")
+	assert.NoError(t, os.WriteFile(keyPath, priv, 0600))
+	assert.NoError(t, os.WriteFile(pubPath, pub, 0600))
+
+	signer := &Ed25519Signer{KeyPath: keyPath}
+	var sigBuf bytes.Buffer
+	assert.NoError(t, signer.Sign(strings.NewReader("original"), &sigBuf))
+
+	verifier := &Ed25519Verifier{KeyringPath: pubPath}
+	err = verifier.Verify(strings.NewReader("tampered"), bytes.NewReader(sigBuf.Bytes()))
+	assert.Error(t, err)
+}
+
+func TestNewSignerUnsupportedMode(t *testing.T) {
+	_, err := NewSigner(Mode("pgp2"), KeyConfig{})
+	assert.ErrorIs(t, err, ErrUnsupportedMode)
+}
@@ -0,0 +1,62 @@
+package sign
+
+import "os"
+
+// SignFile signs the file at path with mode/cfg, writing the detached
+// signature to path+SigExtension (and, for cosign, a matching
+// "<path>.cert" signing certificate and "<path>.bundle").
+func SignFile(mode Mode, cfg KeyConfig, path string) error {
+	if mode == ModeCosign {
+		signer := &CosignSigner{OIDCIssuer: cfg.OIDCIssuer}
+
+		return signer.SignFile(path, SigPath(path), path+".cert")
+	}
+
+	signer, err := NewSigner(mode, cfg)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = content.Close() }()
+
+	sigFile, err := os.Create(SigPath(path))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = sigFile.Close() }()
+
+	return signer.Sign(content, sigFile)
+}
+
+// VerifyFile verifies path against its detached signature (and, for
+// cosign, its signing certificate) using mode/cfg.
+func VerifyFile(mode Mode, cfg KeyConfig, path string) error {
+	if mode == ModeCosign {
+		verifier := &CosignVerifier{OIDCIssuer: cfg.OIDCIssuer, CertIdentity: cfg.CertIdentity}
+
+		return verifier.VerifyFile(path, SigPath(path), path+".cert")
+	}
+
+	verifier, err := NewVerifier(mode, cfg)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = content.Close() }()
+
+	sigFile, err := os.Open(SigPath(path))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = sigFile.Close() }()
+
+	return verifier.Verify(content, sigFile)
+}
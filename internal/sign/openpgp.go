@@ -0,0 +1,67 @@
+package sign
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck // openpgp is deprecated upstream but still the simplest armored detached-signature implementation available
+)
+
+// ErrEmptyKeyring is returned when an armored key/keyring file parses
+// successfully but contains no entities, e.g. an empty or malformed block.
+var ErrEmptyKeyring = errors.New("armored key contains no entities")
+
+// OpenPGPSigner produces an armored OpenPGP detached signature using the
+// private key at KeyPath.
+type OpenPGPSigner struct {
+	KeyPath string
+}
+
+// Sign streams content through openpgp's armored detached-signing API,
+// which hashes as it reads rather than buffering the whole file.
+func (s *OpenPGPSigner) Sign(content io.Reader, sig io.Writer) error {
+	keyFile, err := os.Open(s.KeyPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = keyFile.Close() }()
+
+	entityList, err := openpgp.ReadArmoredKeyRing(keyFile)
+	if err != nil {
+		return err
+	}
+	if len(entityList) == 0 {
+		return ErrEmptyKeyring
+	}
+
+	return openpgp.ArmoredDetachSign(sig, entityList[0], content, nil)
+}
+
+// OpenPGPVerifier checks an armored OpenPGP detached signature against a
+// keyring at KeyringPath.
+type OpenPGPVerifier struct {
+	KeyringPath string
+}
+
+// Verify streams content through openpgp's armored detached-signature
+// check, which hashes as it reads rather than buffering the whole file.
+func (v *OpenPGPVerifier) Verify(content io.Reader, sig io.Reader) error {
+	keyringFile, err := os.Open(v.KeyringPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = keyringFile.Close() }()
+
+	keyring, err := openpgp.ReadArmoredKeyRing(keyringFile)
+	if err != nil {
+		return err
+	}
+	if len(keyring) == 0 {
+		return ErrEmptyKeyring
+	}
+
+	_, err = openpgp.CheckArmoredDetachedSignature(keyring, content, sig)
+
+	return err
+}
@@ -0,0 +1,84 @@
+// Package sign provides detached-signature support for scan results and
+// fingerprint files, so downstream consumers can verify an artifact was
+// produced by a trusted CI run and not tampered with in transit.
+package sign
+
+import (
+	"errors"
+	"io"
+)
+
+// Mode selects which Signer/Verifier implementation to use.
+type Mode string
+
+const (
+	ModeGPG     Mode = "gpg"
+	ModeEd25519 Mode = "ed25519"
+	ModeCosign  Mode = "cosign"
+)
+
+// SigExtension is appended to a signed file's name to produce its detached
+// signature's path, e.g. "debricked-fingerprints.txt.sig".
+const SigExtension = ".sig"
+
+// ErrUnsupportedMode is returned by NewSigner/NewVerifier for an unknown Mode.
+var ErrUnsupportedMode = errors.New("unsupported signing mode")
+
+// Signer produces a detached signature for content, writing it to sig.
+// Implementations must stream over content rather than buffering it, so
+// multi-GB fingerprint files can still be signed.
+type Signer interface {
+	Sign(content io.Reader, sig io.Writer) error
+}
+
+// Verifier checks a detached signature against content.
+type Verifier interface {
+	Verify(content io.Reader, sig io.Reader) error
+}
+
+// KeyConfig configures how a Signer or Verifier resolves key material.
+type KeyConfig struct {
+	// KeyPath is a private key file path (gpg, ed25519) used for signing.
+	KeyPath string
+	// KeyringPath is a public keyring/key file path used for verification.
+	KeyringPath string
+	// OIDCIssuer is the expected OIDC issuer identity asserted by a cosign
+	// keyless signature, e.g. "https://token.actions.githubusercontent.com".
+	OIDCIssuer string
+	// CertIdentity is the expected SAN/identity on a cosign signing
+	// certificate, e.g. a GitHub Actions workflow ref.
+	CertIdentity string
+}
+
+// NewSigner returns the Signer for mode.
+func NewSigner(mode Mode, cfg KeyConfig) (Signer, error) {
+	switch mode {
+	case ModeGPG:
+		return &OpenPGPSigner{KeyPath: cfg.KeyPath}, nil
+	case ModeEd25519:
+		return &Ed25519Signer{KeyPath: cfg.KeyPath}, nil
+	case ModeCosign:
+		return &CosignSigner{OIDCIssuer: cfg.OIDCIssuer}, nil
+	default:
+		return nil, ErrUnsupportedMode
+	}
+}
+
+// NewVerifier returns the Verifier for mode.
+func NewVerifier(mode Mode, cfg KeyConfig) (Verifier, error) {
+	switch mode {
+	case ModeGPG:
+		return &OpenPGPVerifier{KeyringPath: cfg.KeyringPath}, nil
+	case ModeEd25519:
+		return &Ed25519Verifier{KeyringPath: cfg.KeyringPath}, nil
+	case ModeCosign:
+		return &CosignVerifier{OIDCIssuer: cfg.OIDCIssuer, CertIdentity: cfg.CertIdentity}, nil
+	default:
+		return nil, ErrUnsupportedMode
+	}
+}
+
+// SigPath returns the detached-signature path for a signed file.
+func SigPath(path string) string {
+	return path + SigExtension
+}